@@ -0,0 +1,94 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package buildpack
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Masterminds/semver"
+	"github.com/buildpack/libbuildpack/stack"
+)
+
+// Dependencies is a collection of Dependency instances.
+type Dependencies []Dependency
+
+// Best returns the best (latest version) dependency within a collection of Dependencies.  The candidate set is first
+// filtered by id and version, then by compatibility with target, falling back to the legacy stack match if target is
+// empty or no dependency declares a matching Target.  The remaining candidates are sorted for the best result.  If
+// the versionConstraint is not specified (""), then the latest wildcard ("*") is used.
+func (d Dependencies) Best(id string, versionConstraint string, stack stack.Stack, target Target) (Dependency, error) {
+	vc := versionConstraint
+	if vc == "" {
+		vc = "*"
+	}
+
+	constraint, err := semver.NewConstraint(vc)
+	if err != nil {
+		return Dependency{}, err
+	}
+
+	var byID Dependencies
+	for _, c := range d {
+		if c.ID == id && constraint.Check(c.Version.Version) {
+			byID = append(byID, c)
+		}
+	}
+
+	var candidates Dependencies
+
+	if !target.IsEmpty() {
+		for _, c := range byID {
+			if c.MatchesTarget(target) {
+				candidates = append(candidates, c)
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		for _, c := range byID {
+			if c.Stacks.contains(stack) {
+				candidates = append(candidates, c)
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		return Dependency{}, fmt.Errorf("no valid dependencies for %s, %s, %s, and %s in %s; available targets: %s",
+			id, vc, stack, target, d, byID.targets())
+	}
+
+	sort.Slice(candidates, func(i int, j int) bool {
+		return candidates[i].Version.LessThan(candidates[j].Version.Version)
+	})
+
+	return candidates[len(candidates)-1], nil
+}
+
+// targets returns the string representation of every Target declared across the collection, for inclusion in error
+// messages.
+func (d Dependencies) targets() []string {
+	var targets []string
+
+	for _, c := range d {
+		for _, t := range c.Targets {
+			targets = append(targets, t.String())
+		}
+	}
+
+	return targets
+}
@@ -0,0 +1,285 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package buildpack_test
+
+import (
+	"testing"
+
+	"github.com/cloudfoundry/libcfbuildpack/buildpack"
+	"github.com/cloudfoundry/libcfbuildpack/internal"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+)
+
+func TestDependencies(t *testing.T) {
+	spec.Run(t, "Dependencies", func(t *testing.T, _ spec.G, it spec.S) {
+
+		g := NewGomegaWithT(t)
+
+		it("filters by id", func() {
+			d := buildpack.Dependencies{
+				buildpack.Dependency{
+					ID:      "test-id-1",
+					Name:    "test-name",
+					Version: internal.NewTestVersion(t, "1.0"),
+					URI:     "test-uri",
+					SHA256:  "test-sha256",
+					Stacks:  buildpack.Stacks{"test-stack-1", "test-stack-2"}},
+				buildpack.Dependency{
+					ID:      "test-id-2",
+					Name:    "test-name",
+					Version: internal.NewTestVersion(t, "1.0"),
+					URI:     "test-uri",
+					SHA256:  "test-sha256",
+					Stacks:  buildpack.Stacks{"test-stack-1", "test-stack-2"}},
+			}
+
+			expected := buildpack.Dependency{
+				ID:      "test-id-2",
+				Name:    "test-name",
+				Version: internal.NewTestVersion(t, "1.0"),
+				URI:     "test-uri",
+				SHA256:  "test-sha256",
+				Stacks:  buildpack.Stacks{"test-stack-1", "test-stack-2"}}
+
+			g.Expect(d.Best("test-id-2", "1.0", "test-stack-1", buildpack.Target{})).To(Equal(expected))
+		})
+
+		it("filters by version constraint", func() {
+			d := buildpack.Dependencies{
+				buildpack.Dependency{
+					ID:      "test-id",
+					Name:    "test-name",
+					Version: internal.NewTestVersion(t, "1.0"),
+					URI:     "test-uri",
+					SHA256:  "test-sha256",
+					Stacks:  buildpack.Stacks{"test-stack-1", "test-stack-2"}},
+				buildpack.Dependency{
+					ID:      "test-id",
+					Name:    "test-name",
+					Version: internal.NewTestVersion(t, "2.0"),
+					URI:     "test-uri",
+					SHA256:  "test-sha256",
+					Stacks:  buildpack.Stacks{"test-stack-1", "test-stack-2"}},
+			}
+
+			expected := buildpack.Dependency{
+				ID:      "test-id",
+				Name:    "test-name",
+				Version: internal.NewTestVersion(t, "2.0"),
+				URI:     "test-uri",
+				SHA256:  "test-sha256",
+				Stacks:  buildpack.Stacks{"test-stack-1", "test-stack-2"}}
+
+			g.Expect(d.Best("test-id", "2.0", "test-stack-1", buildpack.Target{})).To(Equal(expected))
+		})
+
+		it("filters by stack", func() {
+			d := buildpack.Dependencies{
+				buildpack.Dependency{
+					ID:      "test-id",
+					Name:    "test-name",
+					Version: internal.NewTestVersion(t, "1.0"),
+					URI:     "test-uri",
+					SHA256:  "test-sha256",
+					Stacks:  buildpack.Stacks{"test-stack-1", "test-stack-2"}},
+				buildpack.Dependency{
+					ID:      "test-id",
+					Name:    "test-name",
+					Version: internal.NewTestVersion(t, "1.0"),
+					URI:     "test-uri",
+					SHA256:  "test-sha256",
+					Stacks:  buildpack.Stacks{"test-stack-1", "test-stack-3"}},
+			}
+
+			expected := buildpack.Dependency{
+				ID:      "test-id",
+				Name:    "test-name",
+				Version: internal.NewTestVersion(t, "1.0"),
+				URI:     "test-uri",
+				SHA256:  "test-sha256",
+				Stacks:  buildpack.Stacks{"test-stack-1", "test-stack-3"}}
+
+			g.Expect(d.Best("test-id", "1.0", "test-stack-3", buildpack.Target{})).To(Equal(expected))
+		})
+
+		it("returns the best dependency", func() {
+			d := buildpack.Dependencies{
+				buildpack.Dependency{
+					ID:      "test-id",
+					Name:    "test-name",
+					Version: internal.NewTestVersion(t, "1.1"),
+					URI:     "test-uri",
+					SHA256:  "test-sha256",
+					Stacks:  buildpack.Stacks{"test-stack-1", "test-stack-2"}},
+				buildpack.Dependency{
+					ID:      "test-id",
+					Name:    "test-name",
+					Version: internal.NewTestVersion(t, "1.0"),
+					URI:     "test-uri",
+					SHA256:  "test-sha256",
+					Stacks:  buildpack.Stacks{"test-stack-1", "test-stack-3"}},
+			}
+
+			expected := buildpack.Dependency{
+				ID:      "test-id",
+				Name:    "test-name",
+				Version: internal.NewTestVersion(t, "1.1"),
+				URI:     "test-uri",
+				SHA256:  "test-sha256",
+				Stacks:  buildpack.Stacks{"test-stack-1", "test-stack-2"}}
+
+			g.Expect(d.Best("test-id", "1.*", "test-stack-1", buildpack.Target{})).To(Equal(expected))
+		})
+
+		it("returns the best dependency when non-candidates are interleaved with the real candidates", func() {
+			d := buildpack.Dependencies{
+				buildpack.Dependency{
+					ID:      "other-id",
+					Name:    "test-name",
+					Version: internal.NewTestVersion(t, "9.0"),
+					URI:     "test-uri",
+					SHA256:  "test-sha256",
+					Stacks:  buildpack.Stacks{"test-stack-1"}},
+				buildpack.Dependency{
+					ID:      "test-id",
+					Name:    "test-name",
+					Version: internal.NewTestVersion(t, "1.0"),
+					URI:     "test-uri",
+					SHA256:  "test-sha256",
+					Stacks:  buildpack.Stacks{"test-stack-1"}},
+				buildpack.Dependency{
+					ID:      "test-id",
+					Name:    "test-name",
+					Version: internal.NewTestVersion(t, "2.0"),
+					URI:     "test-uri",
+					SHA256:  "test-sha256",
+					Stacks:  buildpack.Stacks{"test-stack-1"}},
+			}
+
+			expected := d[2]
+
+			g.Expect(d.Best("test-id", "*", "test-stack-1", buildpack.Target{})).To(Equal(expected))
+		})
+
+		it("returns error if there are no matching dependencies", func() {
+			d := buildpack.Dependencies{
+				buildpack.Dependency{
+					ID:      "test-id",
+					Name:    "test-name",
+					Version: internal.NewTestVersion(t, "1.0"),
+					URI:     "test-uri",
+					SHA256:  "test-sha256",
+					Stacks:  buildpack.Stacks{"test-stack-1", "test-stack-2"}},
+				buildpack.Dependency{
+					ID:      "test-id",
+					Name:    "test-name",
+					Version: internal.NewTestVersion(t, "1.0"),
+					URI:     "test-uri",
+					SHA256:  "test-sha256",
+					Stacks:  buildpack.Stacks{"test-stack-1", "test-stack-3"}},
+			}
+
+			_, err := d.Best("test-id-2", "1.0", "test-stack-1", buildpack.Target{})
+			g.Expect(err).To(HaveOccurred())
+		})
+
+		it("substitutes all wildcard for unspecified version constraint", func() {
+			d := buildpack.Dependencies{
+				buildpack.Dependency{
+					ID:      "test-id",
+					Name:    "test-name",
+					Version: internal.NewTestVersion(t, "1.1"),
+					URI:     "test-uri",
+					SHA256:  "test-sha256",
+					Stacks:  buildpack.Stacks{"test-stack-1", "test-stack-2"}},
+			}
+
+			expected := buildpack.Dependency{
+				ID:      "test-id",
+				Name:    "test-name",
+				Version: internal.NewTestVersion(t, "1.1"),
+				URI:     "test-uri",
+				SHA256:  "test-sha256",
+				Stacks:  buildpack.Stacks{"test-stack-1", "test-stack-2"}}
+
+			g.Expect(d.Best("test-id", "", "test-stack-1", buildpack.Target{})).To(Equal(expected))
+		})
+
+		it("filters by target, in preference to stack", func() {
+			d := buildpack.Dependencies{
+				buildpack.Dependency{
+					ID:      "test-id",
+					Name:    "test-name",
+					Version: internal.NewTestVersion(t, "1.0"),
+					URI:     "test-uri",
+					SHA256:  "test-sha256",
+					Stacks:  buildpack.Stacks{"test-stack-1"},
+					Targets: []buildpack.Target{{OS: "linux", Arch: "amd64"}}},
+				buildpack.Dependency{
+					ID:      "test-id",
+					Name:    "test-name",
+					Version: internal.NewTestVersion(t, "1.0"),
+					URI:     "test-uri",
+					SHA256:  "test-sha256",
+					Stacks:  buildpack.Stacks{"test-stack-1"},
+					Targets: []buildpack.Target{{OS: "linux", Arch: "arm64", Distribution: buildpack.Distribution{Name: "ubuntu", Version: "22.04"}}}},
+			}
+
+			expected := d[1]
+
+			target := buildpack.Target{OS: "linux", Arch: "arm64", Distribution: buildpack.Distribution{Name: "ubuntu", Version: "22.04"}}
+			g.Expect(d.Best("test-id", "1.0", "test-stack-1", target)).To(Equal(expected))
+		})
+
+		it("falls back to the legacy stack match when no target matches", func() {
+			d := buildpack.Dependencies{
+				buildpack.Dependency{
+					ID:      "test-id",
+					Name:    "test-name",
+					Version: internal.NewTestVersion(t, "1.0"),
+					URI:     "test-uri",
+					SHA256:  "test-sha256",
+					Stacks:  buildpack.Stacks{"test-stack-1"},
+					Targets: []buildpack.Target{{OS: "linux", Arch: "amd64"}}},
+			}
+
+			expected := d[0]
+
+			target := buildpack.Target{OS: "windows", Arch: "amd64"}
+			g.Expect(d.Best("test-id", "1.0", "test-stack-1", target)).To(Equal(expected))
+		})
+
+		it("lists available targets when no dependency matches", func() {
+			d := buildpack.Dependencies{
+				buildpack.Dependency{
+					ID:      "test-id",
+					Name:    "test-name",
+					Version: internal.NewTestVersion(t, "1.0"),
+					URI:     "test-uri",
+					SHA256:  "test-sha256",
+					Stacks:  buildpack.Stacks{"test-stack-1"},
+					Targets: []buildpack.Target{{OS: "linux", Arch: "amd64"}}},
+			}
+
+			target := buildpack.Target{OS: "windows", Arch: "amd64"}
+			_, err := d.Best("test-id", "1.0", "test-stack-2", target)
+			g.Expect(err).To(MatchError(ContainSubstring("Target{ OS: linux, Arch: amd64")))
+		})
+	}, spec.Report(report.Terminal{}))
+}
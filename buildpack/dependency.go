@@ -0,0 +1,194 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package buildpack
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// DeprecationStatus describes how close a Dependency is to, or past, its DeprecationDate.
+type DeprecationStatus int
+
+const (
+	// NotDeprecated indicates that a dependency has no deprecation date, or is not yet within the warning window.
+	NotDeprecated DeprecationStatus = iota
+
+	// DeprecationImminent indicates that a dependency's deprecation date is within the warning window.
+	DeprecationImminent
+
+	// Deprecated indicates that a dependency's deprecation date has passed.
+	Deprecated
+)
+
+// deprecationWarningWindow is how far ahead of a DeprecationDate a Dependency is considered imminently deprecated.
+const deprecationWarningWindow = 30 * 24 * time.Hour
+
+// Dependency represents a buildpack dependency.
+type Dependency struct {
+	// ID is the dependency ID.
+	ID string `mapstruct:"id" toml:"id"`
+
+	// Name is the dependency ID.
+	Name string `mapstruct:"name" toml:"name"`
+
+	// Version is the dependency version.
+	Version Version `mapstruct:"version" toml:"version"`
+
+	// URI is the dependency URI.
+	URI string `mapstruct:"uri" toml:"uri"`
+
+	// SHA256 is the hash of the dependency.
+	SHA256 string `mapstruct:"sha256" toml:"sha256"`
+
+	// Stacks are the stacks the dependency is compatible with.
+	Stacks Stacks `mapstruct:"stacks" toml:"stacks"`
+
+	// Targets are the OS/architecture/distribution combinations the dependency is compatible with.  When present,
+	// they take precedence over Stacks during dependency selection.
+	Targets []Target `mapstruct:"targets" toml:"targets,omitempty"`
+
+	// Licenses are the stacks the dependency is distributed under.
+	Licenses Licenses `mapstruct:"licenses" toml:"licenses"`
+
+	// PURL is the package-url (purl-spec) identifier for the dependency.
+	PURL string `mapstruct:"purl" toml:"purl"`
+
+	// CPEs are the CPE 2.3 identifiers for the dependency.
+	CPEs []string `mapstruct:"cpes" toml:"cpes"`
+
+	// Source is the URI of the dependency's source artifact, for buildpacks that must ship source alongside
+	// binaries for SBOM provenance or license compliance.
+	Source string `mapstruct:"source" toml:"source,omitempty"`
+
+	// SourceSHA256 is the hash of the dependency's source artifact.
+	SourceSHA256 string `mapstruct:"source_sha256" toml:"source_sha256,omitempty"`
+
+	// DeprecationDate is the date on which the dependency is deprecated.
+	DeprecationDate *time.Time `mapstruct:"deprecation-date" toml:"deprecation-date"`
+}
+
+// DecodeDependency decodes a single dependency entry from the generic map representation produced by TOML or JSON
+// decoding (as used in buildpack.toml and extension.toml metadata) into a Dependency.  It is shared by Buildpack and
+// extension.Extension so that the two can resolve dependencies identically.
+func DecodeDependency(dep map[string]interface{}) (Dependency, error) {
+	var d Dependency
+
+	config := mapstructure.DecoderConfig{
+		DecodeHook: unmarshalText,
+		TagName:    "mapstruct",
+		Result:     &d,
+	}
+
+	decoder, err := mapstructure.NewDecoder(&config)
+	if err != nil {
+		return Dependency{}, err
+	}
+
+	if err := decoder.Decode(dep); err != nil {
+		return Dependency{}, err
+	}
+
+	return d, nil
+}
+
+// Identity make Buildpack satisfy the Identifiable interface.
+func (d Dependency) Identity() (string, string) {
+	if d.Version.Version != nil {
+		return d.Name, d.Version.Version.Original()
+	}
+
+	return d.Name, ""
+}
+
+// NormalizedLicenses returns the canonical form of the dependency's licenses, for consumers such as dependency
+// resolvers and SBOM writers that need a consistent shape regardless of how the license was authored in
+// buildpack.toml.
+func (d Dependency) NormalizedLicenses() []License {
+	return d.Licenses.Normalize()
+}
+
+// DeprecationStatus returns the deprecation status of the dependency, relative to now.
+func (d Dependency) DeprecationStatus() DeprecationStatus {
+	if d.DeprecationDate == nil {
+		return NotDeprecated
+	}
+
+	switch until := time.Until(*d.DeprecationDate); {
+	case until < 0:
+		return Deprecated
+	case until <= deprecationWarningWindow:
+		return DeprecationImminent
+	default:
+		return NotDeprecated
+	}
+}
+
+// String makes Dependency satisfy the Stringer interface.
+func (d Dependency) String() string {
+	return fmt.Sprintf("Dependency{ ID: %s, Name: %s, Version: %s, URI: %s, SHA256: %s, Stacks: %s, Targets: %s, Licenses: %s, PURL: %s, CPEs: %s, Source: %s, SourceSHA256: %s, DeprecationDate: %s }",
+		d.ID, d.Name, d.Version, d.URI, d.SHA256, d.Stacks, d.Targets, d.Licenses, d.PURL, d.CPEs, d.Source, d.SourceSHA256, d.DeprecationDate)
+}
+
+// MatchesTarget indicates whether the dependency declares a Target compatible with target.  It returns false if the
+// dependency has no Targets, regardless of target.
+func (d Dependency) MatchesTarget(target Target) bool {
+	for _, t := range d.Targets {
+		if t.Matches(target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate ensures that the dependency is valid.
+func (d Dependency) Validate() error {
+	if "" == d.ID {
+		return fmt.Errorf("id is required")
+	}
+
+	if "" == d.Name {
+		return fmt.Errorf("name is required")
+	}
+
+	if (Version{} == d.Version) {
+		return fmt.Errorf("version is required")
+	}
+
+	if "" == d.URI {
+		return fmt.Errorf("uri is required")
+	}
+
+	if "" == d.SHA256 {
+		return fmt.Errorf("sha256 is required")
+	}
+
+	if len(d.Targets) == 0 {
+		if err := d.Stacks.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := d.Licenses.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package buildpack
+
+import (
+	"fmt"
+)
+
+// License represents a license that a Dependency is distributed under.  At least one of Type, SPDXID, Name, or URI
+// MUST be specified.  A license may be authored in buildpack.toml as a bare SPDX identifier string (e.g.
+// "Apache-2.0"), which is equivalent to setting SPDXID alone.
+type License struct {
+	// Type is the type of the license.  This is typically the SPDX short identifier.  Deprecated: use SPDXID instead.
+	Type string `mapstruct:"type" toml:"type,omitempty"`
+
+	// SPDXID is the SPDX short identifier of the license, e.g. "Apache-2.0".
+	SPDXID string `mapstruct:"spdx-id" toml:"spdx-id,omitempty"`
+
+	// Name is the human readable name of the license, for licenses that do not have an SPDX identifier.
+	Name string `mapstruct:"name" toml:"name,omitempty"`
+
+	// URI is the location where the license can be found.
+	URI string `mapstruct:"uri" toml:"uri,omitempty"`
+}
+
+// String makes License satisfy the Stringer interface.
+func (l License) String() string {
+	return fmt.Sprintf("License{ Type: %s, SPDXID: %s, Name: %s, URI: %s }", l.Type, l.SPDXID, l.Name, l.URI)
+}
+
+// Validate ensures that license has at least one of type, spdx-id, name, or uri
+func (l License) Validate() error {
+	if "" == l.Type && "" == l.SPDXID && "" == l.Name && "" == l.URI {
+		return fmt.Errorf("license must have at least one of type, spdx-id, name, or uri")
+	}
+
+	return nil
+}
+
+// normalize returns the canonical form of the license, preferring SPDXID over the deprecated Type field and falling
+// back to Name when neither is set, so that licenses with no SPDX identifier still resolve to a usable label.
+func (l License) normalize() License {
+	if l.SPDXID == "" {
+		l.SPDXID = l.Type
+	}
+
+	if l.SPDXID == "" {
+		l.SPDXID = l.Name
+	}
+
+	return l
+}
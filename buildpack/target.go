@@ -0,0 +1,111 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package buildpack
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cloudfoundry/libcfbuildpack/logger"
+)
+
+// Distribution identifies an OS distribution and version that a Target applies to.
+type Distribution struct {
+	// Name is the distribution name, e.g. "ubuntu".
+	Name string `mapstruct:"name" toml:"name,omitempty"`
+
+	// Version is the distribution version, e.g. "22.04".
+	Version string `mapstruct:"version" toml:"version,omitempty"`
+}
+
+// String makes Distribution satisfy the Stringer interface.
+func (d Distribution) String() string {
+	return fmt.Sprintf("Distribution{ Name: %s, Version: %s }", d.Name, d.Version)
+}
+
+// Target identifies an OS, architecture, and distribution that a Dependency is compatible with.  A field left empty
+// acts as a wildcard when matching against another Target, e.g. a Target with only OS and Arch set matches every
+// Distribution of that OS/architecture.
+type Target struct {
+	// OS is the operating system, e.g. "linux".
+	OS string `mapstruct:"os" toml:"os,omitempty"`
+
+	// Arch is the hardware architecture, e.g. "amd64".
+	Arch string `mapstruct:"arch" toml:"arch,omitempty"`
+
+	// ArchVariant is the variant of Arch, e.g. "v7" for 32-bit ARM.
+	ArchVariant string `mapstruct:"arch-variant" toml:"arch-variant,omitempty"`
+
+	// Distribution is the OS distribution and version.
+	Distribution Distribution `mapstruct:"distribution" toml:"distribution,omitempty"`
+}
+
+// String makes Target satisfy the Stringer interface.
+func (t Target) String() string {
+	return fmt.Sprintf("Target{ OS: %s, Arch: %s, ArchVariant: %s, Distribution: %s }",
+		t.OS, t.Arch, t.ArchVariant, t.Distribution)
+}
+
+// IsEmpty indicates whether the target has no fields set, i.e. it does not target anything in particular.
+func (t Target) IsEmpty() bool {
+	return t == Target{}
+}
+
+// Matches indicates whether t is compatible with other.  A field left empty in t acts as a wildcard, matching any
+// value of that field in other.
+func (t Target) Matches(other Target) bool {
+	if t.OS != "" && t.OS != other.OS {
+		return false
+	}
+
+	if t.Arch != "" && t.Arch != other.Arch {
+		return false
+	}
+
+	if t.ArchVariant != "" && t.ArchVariant != other.ArchVariant {
+		return false
+	}
+
+	if t.Distribution.Name != "" && t.Distribution.Name != other.Distribution.Name {
+		return false
+	}
+
+	if t.Distribution.Version != "" && t.Distribution.Version != other.Distribution.Version {
+		return false
+	}
+
+	return true
+}
+
+// DefaultTarget creates a new instance of Target, extracting its fields from the CNB_TARGET_* environment variables.
+// Any variable that is unset leaves the corresponding field empty, which acts as a wildcard during dependency
+// selection.
+func DefaultTarget(logger logger.Logger) Target {
+	t := Target{
+		OS:          os.Getenv("CNB_TARGET_OS"),
+		Arch:        os.Getenv("CNB_TARGET_ARCH"),
+		ArchVariant: os.Getenv("CNB_TARGET_ARCH_VARIANT"),
+		Distribution: Distribution{
+			Name:    os.Getenv("CNB_TARGET_DISTRO_NAME"),
+			Version: os.Getenv("CNB_TARGET_DISTRO_VERSION"),
+		},
+	}
+
+	logger.Debug("Target: %s", t)
+
+	return t
+}
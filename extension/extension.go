@@ -0,0 +1,189 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package extension models buildpack extensions, the generate-time counterpart to buildpack/Buildpack that
+// contributes Dockerfile fragments instead of launch layers.
+package extension
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"github.com/cloudfoundry/libcfbuildpack/buildpack"
+	"github.com/cloudfoundry/libcfbuildpack/logger"
+)
+
+const cacheRoot = "dependency-cache"
+
+// Configuration describes a build-time configuration option that the extension's Dockerfile fragments can be
+// parameterized with, e.g. via a Dockerfile ARG.
+type Configuration struct {
+	// Name is the name of the configuration option.
+	Name string `mapstruct:"name" toml:"name"`
+
+	// Build indicates whether the option influences the build image.
+	Build bool `mapstruct:"build" toml:"build"`
+
+	// Default is the value used if the option is not otherwise specified.
+	Default string `mapstruct:"default" toml:"default"`
+
+	// Description describes the effect of the configuration option.
+	Description string `mapstruct:"description" toml:"description"`
+}
+
+// String makes Configuration satisfy the Stringer interface.
+func (c Configuration) String() string {
+	return fmt.Sprintf("Configuration{ Name: %s, Build: %t, Default: %s, Description: %s }",
+		c.Name, c.Build, c.Default, c.Description)
+}
+
+// ExtensionConfig represents the metadata associated with a buildpack extension, as declared in extension.toml.
+type ExtensionConfig struct {
+	// API is the version of the Buildpack API that the extension adheres to.
+	API string `toml:"api"`
+
+	// Extension is information about the extension.
+	Extension Info `toml:"extension"`
+
+	// Metadata is the additional metadata included in the extension.
+	Metadata buildpack.Metadata `toml:"metadata"`
+
+	// CacheRoot is the path to the root directory for the extension's dependency cache.
+	CacheRoot string
+
+	// Root is the path to the root directory for the extension.
+	Root string
+
+	logger logger.Logger
+}
+
+// Dependencies returns the collection of dependencies extracted from the generic extension metadata.
+func (e ExtensionConfig) Dependencies() (buildpack.Dependencies, error) {
+	deps, ok := e.Metadata["dependencies"].([]map[string]interface{})
+	if !ok {
+		return buildpack.Dependencies{}, nil
+	}
+
+	var dependencies buildpack.Dependencies
+	for _, dep := range deps {
+		d, err := buildpack.DecodeDependency(dep)
+		if err != nil {
+			return buildpack.Dependencies{}, err
+		}
+
+		dependencies = append(dependencies, d)
+	}
+
+	e.logger.Debug("Dependencies: %s", dependencies)
+	return dependencies, nil
+}
+
+// DefaultVersions returns the default-versions extension metadata, a map of dependency id to version constraint used
+// when a buildpack does not otherwise request a specific version.
+func (e ExtensionConfig) DefaultVersions() (map[string]string, error) {
+	raw, ok := e.Metadata["default-versions"].(map[string]interface{})
+	if !ok {
+		return map[string]string{}, nil
+	}
+
+	versions := make(map[string]string, len(raw))
+	for id, v := range raw {
+		version, ok := v.(string)
+		if !ok {
+			return map[string]string{}, fmt.Errorf("default-versions.%s is not a string", id)
+		}
+
+		versions[id] = version
+	}
+
+	return versions, nil
+}
+
+// Configurations returns the configurations extension metadata.
+func (e ExtensionConfig) Configurations() ([]Configuration, error) {
+	raw, ok := e.Metadata["configurations"].([]map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	var configurations []Configuration
+	for _, c := range raw {
+		name, _ := c["name"].(string)
+		build, _ := c["build"].(bool)
+		def, _ := c["default"].(string)
+		description, _ := c["description"].(string)
+
+		configurations = append(configurations, Configuration{Name: name, Build: build, Default: def, Description: description})
+	}
+
+	return configurations, nil
+}
+
+// IncludeFiles returns the include-files extension metadata.
+func (e ExtensionConfig) IncludeFiles() ([]string, error) {
+	files, ok := e.Metadata["include-files"].([]interface{})
+	if !ok {
+		return []string{}, nil
+	}
+
+	var includes []string
+	for _, candidate := range files {
+		file, ok := candidate.(string)
+		if !ok {
+			return []string{}, fmt.Errorf("include-files is not an array of strings")
+		}
+
+		includes = append(includes, file)
+	}
+
+	return includes, nil
+}
+
+// PrePackage returns the pre-package extension metadata.
+func (e ExtensionConfig) PrePackage() (string, bool) {
+	p, ok := e.Metadata["pre-package"].(string)
+	return p, ok
+}
+
+// Identity makes ExtensionConfig satisfy the Identifiable interface.
+func (e ExtensionConfig) Identity() (string, string) {
+	return e.Extension.Name, e.Extension.Version
+}
+
+// String makes ExtensionConfig satisfy the Stringer interface.
+func (e ExtensionConfig) String() string {
+	return fmt.Sprintf("ExtensionConfig{ API: %s, Extension: %s, Metadata: %s, CacheRoot: %s, Root: %s, logger: %s }",
+		e.API, e.Extension, e.Metadata, e.CacheRoot, e.Root, e.logger)
+}
+
+// NewExtensionConfig creates a new instance of ExtensionConfig, extracting the contents of the extension.toml file in
+// the root of the extension.
+func NewExtensionConfig(rootDir string, logger logger.Logger) (ExtensionConfig, error) {
+	f, err := ioutil.ReadFile(filepath.Join(rootDir, "extension.toml"))
+	if err != nil {
+		return ExtensionConfig{}, fmt.Errorf("could not find extension.toml in the directory %s", rootDir)
+	}
+
+	e := ExtensionConfig{CacheRoot: filepath.Join(rootDir, cacheRoot), Root: rootDir, logger: logger}
+	if err := toml.Unmarshal(f, &e); err != nil {
+		return ExtensionConfig{}, err
+	}
+
+	logger.Debug("ExtensionConfig: %s", e)
+	return e, nil
+}
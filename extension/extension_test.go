@@ -0,0 +1,145 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package extension_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudfoundry/libcfbuildpack/buildpack"
+	"github.com/cloudfoundry/libcfbuildpack/extension"
+	"github.com/cloudfoundry/libcfbuildpack/internal"
+	"github.com/cloudfoundry/libcfbuildpack/logger"
+	"github.com/cloudfoundry/libcfbuildpack/test"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+)
+
+func TestExtensionConfig(t *testing.T) {
+	spec.Run(t, "ExtensionConfig", func(t *testing.T, _ spec.G, it spec.S) {
+
+		g := NewGomegaWithT(t)
+
+		it("loads extension.toml from the extension root", func() {
+			root := test.ScratchDir(t, "extension")
+
+			g.Expect(internal.WriteTomlFile(filepath.Join(root, "extension.toml"), 0644, map[string]interface{}{
+				"api": "0.9",
+				"extension": map[string]interface{}{
+					"id":       "test-id",
+					"name":     "test-name",
+					"version":  "1.0",
+					"homepage": "test-homepage",
+				},
+			})).To(Succeed())
+
+			e, err := extension.NewExtensionConfig(root, logger.Logger{})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			g.Expect(e.API).To(Equal("0.9"))
+			g.Expect(e.Extension).To(Equal(extension.Info{ID: "test-id", Name: "test-name", Version: "1.0", Homepage: "test-homepage"}))
+			g.Expect(e.Root).To(Equal(root))
+			g.Expect(e.CacheRoot).To(Equal(filepath.Join(root, "dependency-cache")))
+		})
+
+		it("returns dependencies", func() {
+			e := extension.ExtensionConfig{
+				Metadata: buildpack.Metadata{
+					"dependencies": []map[string]interface{}{
+						{
+							"id":      "test-id",
+							"name":    "test-name",
+							"version": "1.0",
+							"uri":     "test-uri",
+							"sha256":  "test-sha256",
+						},
+					},
+				},
+			}
+
+			dependencies, err := e.Dependencies()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			g.Expect(dependencies).To(Equal(buildpack.Dependencies{
+				buildpack.Dependency{
+					ID:      "test-id",
+					Name:    "test-name",
+					Version: internal.NewTestVersion(t, "1.0"),
+					URI:     "test-uri",
+					SHA256:  "test-sha256",
+				},
+			}))
+		})
+
+		it("returns include-files", func() {
+			e := extension.ExtensionConfig{
+				Metadata: buildpack.Metadata{
+					"include-files": []interface{}{"test-file-1", "test-file-2"},
+				},
+			}
+
+			files, err := e.IncludeFiles()
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(files).To(Equal([]string{"test-file-1", "test-file-2"}))
+		})
+
+		it("returns pre-package", func() {
+			e := extension.ExtensionConfig{
+				Metadata: buildpack.Metadata{"pre-package": "test-script"},
+			}
+
+			p, ok := e.PrePackage()
+			g.Expect(ok).To(BeTrue())
+			g.Expect(p).To(Equal("test-script"))
+		})
+
+		it("returns default-versions", func() {
+			e := extension.ExtensionConfig{
+				Metadata: buildpack.Metadata{
+					"default-versions": map[string]interface{}{"test-id": "1.2.3"},
+				},
+			}
+
+			versions, err := e.DefaultVersions()
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(versions).To(Equal(map[string]string{"test-id": "1.2.3"}))
+		})
+
+		it("returns configurations", func() {
+			e := extension.ExtensionConfig{
+				Metadata: buildpack.Metadata{
+					"configurations": []map[string]interface{}{
+						{
+							"name":        "TEST_CONFIGURATION",
+							"build":       true,
+							"default":     "test-default",
+							"description": "test-description",
+						},
+					},
+				},
+			}
+
+			configurations, err := e.Configurations()
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(configurations).To(Equal([]extension.Configuration{
+				{Name: "TEST_CONFIGURATION", Build: true, Default: "test-default", Description: "test-description"},
+			}))
+		})
+
+	}, spec.Report(report.Terminal{}))
+}
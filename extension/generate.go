@@ -0,0 +1,155 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package extension
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	bp "github.com/buildpack/libbuildpack/layers"
+	bplogger "github.com/buildpack/libbuildpack/logger"
+	"github.com/buildpack/libbuildpack/platform"
+	"github.com/cloudfoundry/libcfbuildpack/buildpack"
+	"github.com/cloudfoundry/libcfbuildpack/internal"
+	"github.com/cloudfoundry/libcfbuildpack/layers"
+	"github.com/cloudfoundry/libcfbuildpack/logger"
+)
+
+// SuccessStatusCode is the status code returned for a successful generate.
+const SuccessStatusCode = 0
+
+// buildDockerfile is the name of the build-time Dockerfile fragment, per the buildpack extensions specification.
+const buildDockerfile = "Dockerfile"
+
+// runDockerfile is the name of the optional run-time Dockerfile fragment, per the buildpack extensions
+// specification.
+const runDockerfile = "run.Dockerfile"
+
+// Generate is an entrypoint, mirroring build.Build, that represents all of the components available to an extension
+// at generate time.
+type Generate struct {
+	// Extension represents the metadata associated with an extension.
+	Extension ExtensionConfig
+
+	// Layers represents the layers an extension can use to cache dependencies between generate executions, reusing
+	// the same DependencyLayer and DownloadLayer plumbing that buildpacks use.
+	Layers layers.Layers
+
+	// Logger is used to write debug and info to the console.
+	Logger logger.Logger
+
+	// Output is the path to the root of the directory that generated Dockerfile fragments are written to.
+	Output string
+
+	// Platform represents components contributed by the platform to the extension.
+	Platform platform.Platform
+}
+
+// String makes Generate satisfy the Stringer interface.
+func (g Generate) String() string {
+	return fmt.Sprintf("Generate{ Extension: %s, Layers: %s, Logger: %s, Output: %s, Platform: %s }",
+		g.Extension, g.Layers, g.Logger, g.Output, g.Platform)
+}
+
+// WriteBuildDockerfile writes a build-time Dockerfile fragment to Output.
+func (g Generate) WriteBuildDockerfile(content string) error {
+	return g.writeDockerfile(buildDockerfile, content)
+}
+
+// WriteRunDockerfile writes an optional run-time Dockerfile fragment to Output.
+func (g Generate) WriteRunDockerfile(content string) error {
+	return g.writeDockerfile(runDockerfile, content)
+}
+
+func (g Generate) writeDockerfile(name string, content string) error {
+	if err := os.MkdirAll(g.Output, 0755); err != nil {
+		return err
+	}
+
+	f := filepath.Join(g.Output, name)
+	g.Logger.Debug("Writing %s", f)
+	return ioutil.WriteFile(f, []byte(content), 0644)
+}
+
+// Failure signals an unsuccessful generate by exiting with a specified positive status code.
+func (g Generate) Failure(code int) int {
+	g.Logger.Debug("Generate failed. Exiting with %d.", code)
+	return code
+}
+
+// Success signals a successful generate by exiting with a zero status code.
+func (g Generate) Success() (int, error) {
+	g.Logger.Debug("Generate success. Exiting with %d.", SuccessStatusCode)
+	return SuccessStatusCode, nil
+}
+
+// DefaultGenerate creates a new instance of Generate using default values, reading the layers, output, and platform
+// directories from the command line arguments in the order in which the buildpack extensions lifecycle supplies
+// them: layers, generated output, platform.
+func DefaultGenerate() (Generate, error) {
+	layersRoot, err := internal.Argument(1)
+	if err != nil {
+		return Generate{}, err
+	}
+
+	outputRoot, err := internal.Argument(2)
+	if err != nil {
+		return Generate{}, err
+	}
+
+	platformRoot, err := internal.Argument(3)
+	if err != nil {
+		return Generate{}, err
+	}
+
+	l, err := bplogger.DefaultLogger(platformRoot)
+	if err != nil {
+		return Generate{}, err
+	}
+	log := logger.Logger{Logger: l}
+
+	extensionRoot, err := os.Getwd()
+	if err != nil {
+		return Generate{}, err
+	}
+
+	extension, err := NewExtensionConfig(extensionRoot, log)
+	if err != nil {
+		return Generate{}, err
+	}
+
+	p, err := platform.DefaultPlatform(platformRoot, l)
+	if err != nil {
+		return Generate{}, err
+	}
+
+	generateLayers := layers.NewLayers(
+		bp.NewLayers(layersRoot, l),
+		bp.NewLayers(extension.CacheRoot, l),
+		buildpack.Buildpack{},
+		log)
+
+	return Generate{
+		Extension: extension,
+		Layers:    generateLayers,
+		Logger:    log,
+		Output:    outputRoot,
+		Platform:  p,
+	}, nil
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package extension_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudfoundry/libcfbuildpack/test"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+)
+
+func TestGenerate(t *testing.T) {
+	spec.Run(t, "Generate", func(t *testing.T, _ spec.G, it spec.S) {
+
+		g := NewGomegaWithT(t)
+
+		it("writes a build-time Dockerfile fragment referencing a cached dependency", func() {
+			f := test.NewGenerateFactory(t)
+
+			fixture := filepath.Join(test.ScratchDir(t, "generate-fixture"), "test-dependency")
+			test.WriteFile(t, fixture, "test-dependency-contents")
+			f.AddDependency("test-id", fixture)
+
+			sha := hex.EncodeToString(sha256.New().Sum([]byte("test-id")))
+			layer := f.Generate.Layers.Layer(sha)
+
+			g.Expect(f.Generate.WriteBuildDockerfile(fmt.Sprintf("FROM test-image\nCOPY --from=test-id %s /\n", layer.Root))).To(Succeed())
+
+			g.Expect(f.BuildDockerfile()).To(ContainSubstring("COPY --from=test-id"))
+		})
+
+		it("writes a run-time Dockerfile fragment referencing a cached dependency", func() {
+			f := test.NewGenerateFactory(t)
+
+			fixture := filepath.Join(test.ScratchDir(t, "generate-fixture"), "test-dependency")
+			test.WriteFile(t, fixture, "test-dependency-contents")
+			f.AddDependency("test-id", fixture)
+
+			sha := hex.EncodeToString(sha256.New().Sum([]byte("test-id")))
+			layer := f.Generate.Layers.Layer(sha)
+
+			g.Expect(f.Generate.WriteRunDockerfile(fmt.Sprintf("FROM test-run-image\nCOPY --from=test-id %s /\n", layer.Root))).To(Succeed())
+
+			g.Expect(f.RunDockerfile()).To(ContainSubstring("COPY --from=test-id"))
+		})
+	}, spec.Report(report.Terminal{}))
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package helper
+
+import (
+	"os"
+	"time"
+)
+
+// CopyFile copies source to destination.  Before writing, it creates all required parent directories for the
+// destination.
+func CopyFile(source string, destination string) error {
+	s, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+
+	defer s.Close()
+
+	i, err := s.Stat()
+	if err != nil {
+		return err
+	}
+
+	return WriteFileFromReader(destination, i.Mode(), s)
+}
+
+// CopyFileWithTimestamp copies source to destination like CopyFile, then normalizes the destination's mtime and mode
+// bits via NormalizeTimestamp, so that the copy is byte-for-byte reproducible.
+func CopyFileWithTimestamp(source string, destination string, timestamp time.Time) error {
+	if err := CopyFile(source, destination); err != nil {
+		return err
+	}
+
+	return NormalizeTimestamp(destination, timestamp)
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package helper
+
+import (
+	"os"
+	"time"
+)
+
+// NormalizeTimestamp sets path's modification time to timestamp and its mode bits to a canonical value (0755 for
+// directories and files with any executable bit set, 0644 otherwise), so that files written by separate,
+// otherwise-identical builds are byte-for-byte reproducible.  Symlinks, whose targets already carry their own
+// permissions, are left untouched.
+func NormalizeTimestamp(path string, timestamp time.Time) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return err
+	}
+
+	if info.Mode()&os.ModeSymlink != 0 {
+		return nil
+	}
+
+	mode := os.FileMode(0644)
+	if info.IsDir() || info.Mode()&0100 != 0 {
+		mode = 0755
+	}
+
+	if err := os.Chmod(path, mode); err != nil {
+		return err
+	}
+
+	return os.Chtimes(path, timestamp, timestamp)
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package helper_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudfoundry/libcfbuildpack/helper"
+	"github.com/cloudfoundry/libcfbuildpack/test"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+)
+
+func TestReproducibility(t *testing.T) {
+	spec.Run(t, "Reproducibility", func(t *testing.T, _ spec.G, it spec.S) {
+
+		g := NewGomegaWithT(t)
+
+		var root string
+
+		it.Before(func() {
+			root = test.ScratchDir(t, "reproducibility")
+		})
+
+		it("normalizes the mtime and mode of a regular file", func() {
+			f := filepath.Join(root, "file.txt")
+			test.WriteFile(t, f, "test-content")
+			g.Expect(os.Chmod(f, 0600)).To(Succeed())
+
+			epoch := time.Unix(0, 0).UTC()
+			g.Expect(helper.NormalizeTimestamp(f, epoch)).To(Succeed())
+
+			info, err := os.Stat(f)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(info.ModTime()).To(BeTemporally("==", epoch))
+			g.Expect(info.Mode()).To(Equal(os.FileMode(0644)))
+		})
+
+		it("preserves the executable bit as a canonical mode", func() {
+			f := filepath.Join(root, "file.sh")
+			test.WriteFile(t, f, "test-content")
+			g.Expect(os.Chmod(f, 0755)).To(Succeed())
+
+			epoch := time.Unix(0, 0).UTC()
+			g.Expect(helper.NormalizeTimestamp(f, epoch)).To(Succeed())
+
+			info, err := os.Stat(f)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(info.Mode()).To(Equal(os.FileMode(0755)))
+		})
+
+		it("copies a file and normalizes its timestamp", func() {
+			source := filepath.Join(root, "source.txt")
+			test.WriteFile(t, source, "test-content")
+
+			destination := filepath.Join(root, "destination.txt")
+			epoch := time.Unix(0, 0).UTC()
+			g.Expect(helper.CopyFileWithTimestamp(source, destination, epoch)).To(Succeed())
+
+			info, err := os.Stat(destination)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(info.ModTime()).To(BeTemporally("==", epoch))
+		})
+
+	}, spec.Report(report.Terminal{}))
+}
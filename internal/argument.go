@@ -0,0 +1,32 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"fmt"
+	"os"
+)
+
+// Argument returns the specified argument from os.Args.  It returns an error if there are not enough arguments to
+// satisfy the request.
+func Argument(index int) (string, error) {
+	if len(os.Args) < index+1 {
+		return "", fmt.Errorf("incorrect number of command line arguments")
+	}
+
+	return os.Args[index], nil
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package layers
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/buildpack/libbuildpack/buildplan"
+	"github.com/cloudfoundry/libcfbuildpack/buildpack"
+	"github.com/cloudfoundry/libcfbuildpack/logger"
+)
+
+// HelperLayer is an extension to Layer that is unique to a buildpack provided helper binary.
+type HelperLayer struct {
+	Layer
+
+	// ID is the id of the buildpack provided helper.
+	ID string
+
+	buildpack            buildpack.Buildpack
+	dependencyBuildPlans buildplan.BuildPlan
+	name                 string
+	logger               logger.Logger
+}
+
+// HelperLayerContributor defines a callback function that is called when a buildpack provided helper needs to be
+// contributed.
+type HelperLayerContributor func(artifact string, layer HelperLayer) error
+
+// Contribute facilitates custom contribution of a buildpack provided helper to a layer.  If the helper has already
+// been contributed, the contribution is validated and the contributor is not called.
+func (l HelperLayer) Contribute(contributor HelperLayerContributor, flags ...Flag) error {
+	if err := l.Layer.Contribute(helperMarker{l.buildpack.Info, l.name}, func(layer Layer) error {
+		licenses, _ := l.buildpack.License()
+
+		var ls []string
+		if licenses != "" {
+			ls = []string{licenses}
+		}
+
+		layer.RegisterSBOMArtifact("helper", l.buildpack.Info.ID, SBOMArtifact{
+			Name:     "helper",
+			Version:  l.buildpack.Info.Version,
+			Licenses: ls,
+			CPEs:     []string{fmt.Sprintf("cpe:2.3:a:%s:%s:%s:*:*:*:*:*:*:*", l.buildpack.Info.ID, l.name, l.buildpack.Info.Version)},
+		})
+
+		artifact := filepath.Join(l.buildpack.Root, "bin", l.ID)
+		l.Layer = layer
+		return contributor(artifact, l)
+	}, flags...); err != nil {
+		return err
+	}
+
+	l.contributeToBuildPlan()
+	return nil
+}
+
+// String makes HelperLayer satisfy the Stringer interface.
+func (l HelperLayer) String() string {
+	return fmt.Sprintf("HelperLayer{ Layer: %s, ID: %s, buildpack: %s, dependencyBuildPlans: %s, name: %s, logger: %s }",
+		l.Layer, l.ID, l.buildpack, l.dependencyBuildPlans, l.name, l.logger)
+}
+
+func (l HelperLayer) contributeToBuildPlan() {
+	l.logger.Debug("Contributing %s to bill-of-materials", l.ID)
+
+	l.dependencyBuildPlans[l.ID] = buildplan.Dependency{
+		Version: l.buildpack.Info.Version,
+		Metadata: buildplan.Metadata{
+			"id":   l.buildpack.Info.ID,
+			"name": l.buildpack.Info.Name,
+		},
+	}
+}
+
+// helperMarker is the metadata written for a contributed helper layer, used to determine whether the layer is
+// already up to date.
+type helperMarker struct {
+	buildpack.Info
+
+	Name string `toml:"name"`
+}
+
+// Identity makes helperMarker satisfy the Identifiable interface.
+func (m helperMarker) Identity() (string, string) {
+	return m.Name, m.Version
+}
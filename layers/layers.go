@@ -0,0 +1,174 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package layers
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/buildpack/libbuildpack/buildplan"
+	"github.com/buildpack/libbuildpack/layers"
+	"github.com/cloudfoundry/libcfbuildpack/buildpack"
+	"github.com/cloudfoundry/libcfbuildpack/logger"
+	"github.com/fatih/color"
+)
+
+// Layers is an extension allows additional functionality to be added.
+type Layers struct {
+	layers.Layers
+
+	// DependencyBuildPlans contains all contributed dependencies.
+	DependencyBuildPlans buildplan.BuildPlan
+
+	// TouchedLayers registers the layers that have been touched during this execution.
+	TouchedLayers TouchedLayers
+
+	// SBOMFormats are the software-bill-of-materials formats that contributed layers should be described in.  An
+	// empty collection, the default, opts out of SBOM generation entirely.
+	SBOMFormats []SBOMFormat
+
+	// SourceDateEpoch is the timestamp applied to files written into a layer root by a Layer configured with the
+	// (default) SourceTimestamp Timestamp, enabling reproducible layer contents.  It defaults to the value of the
+	// SOURCE_DATE_EPOCH environment variable, if set.
+	SourceDateEpoch *time.Time
+
+	buildpack      buildpack.Buildpack
+	buildpackCache layers.Layers
+	logger         logger.Logger
+}
+
+// DependencyLayer returns a DependencyLayer unique to a dependency.
+func (l Layers) DependencyLayer(dependency buildpack.Dependency) DependencyLayer {
+	return DependencyLayer{
+		l.Layer(dependency.ID),
+		dependency,
+		l.DependencyBuildPlans,
+		l.DownloadLayer(dependency),
+		l.SourceLayer(dependency),
+		l.logger,
+	}
+}
+
+// DownloadLayer returns a DownloadLayer unique to a dependency.
+func (l Layers) DownloadLayer(dependency buildpack.Dependency) DownloadLayer {
+	return DownloadLayer{
+		l.Layer(dependency.SHA256),
+		Layer{Layer: l.buildpackCache.Layer(dependency.SHA256), Logger: l.logger, touchedLayers: l.TouchedLayers},
+		dependency,
+		l.logger,
+	}
+}
+
+// SourceLayer returns a SourceLayer unique to a dependency's optional source artifact, cached in a layer sibling to
+// the dependency's DownloadLayer.
+func (l Layers) SourceLayer(dependency buildpack.Dependency) SourceLayer {
+	name := fmt.Sprintf("%s-source", dependency.SHA256)
+
+	return SourceLayer{
+		l.Layer(name),
+		Layer{Layer: l.buildpackCache.Layer(name), Logger: l.logger, touchedLayers: l.TouchedLayers},
+		dependency,
+		l.logger,
+	}
+}
+
+// HelperLayer returns a HelperLayer unique to a buildpack provided helper binary.
+func (l Layers) HelperLayer(id string, name string) HelperLayer {
+	return HelperLayer{
+		l.Layer(id),
+		id,
+		l.buildpack,
+		l.DependencyBuildPlans,
+		name,
+		l.logger,
+	}
+}
+
+// Layer creates a Layer with a specified name.
+func (l Layers) Layer(name string) Layer {
+	return Layer{
+		Layer:           l.Layers.Layer(name),
+		Logger:          l.logger,
+		touchedLayers:   l.TouchedLayers,
+		sbomFormats:     l.SBOMFormats,
+		sbomArtifacts:   &[]sbomRegistration{},
+		sourceDateEpoch: l.SourceDateEpoch,
+	}
+}
+
+// String makes Layers satisfy the Stringer interface.
+func (l Layers) String() string {
+	return fmt.Sprintf("Layers{ Layers: %s, DependencyBuildPlans: %s, TouchedLayers: %s, SBOMFormats: %s, SourceDateEpoch: %s, buildpack: %s, buildpackCache: %s, logger: %s }",
+		l.Layers, l.DependencyBuildPlans, l.TouchedLayers, l.SBOMFormats, l.SourceDateEpoch, l.buildpack, l.buildpackCache, l.logger)
+}
+
+// WriteMetadata writes Launch metadata to the filesystem.
+func (l Layers) WriteMetadata(metadata Metadata) error {
+	l.logger.FirstLine("Process types:")
+
+	max := l.maximumTypeLength(metadata)
+	for _, p := range metadata.Processes {
+		format := fmt.Sprintf("%%s:%%-%ds %%s", max-len(p.Type))
+		l.logger.SubsequentLine(format, color.CyanString(p.Type), "", p.Command)
+	}
+
+	return l.Layers.WriteApplicationMetadata(metadata)
+}
+
+func (l Layers) maximumTypeLength(metadata Metadata) int {
+	max := 0
+
+	for _, t := range metadata.Processes {
+		if l := len(t.Type); l > max {
+			max = l
+		}
+	}
+
+	return max
+}
+
+// NewLayers creates a new instance of Layers.
+func NewLayers(layers layers.Layers, buildpackCache layers.Layers, buildpack buildpack.Buildpack, logger logger.Logger) Layers {
+	return Layers{
+		Layers:               layers,
+		DependencyBuildPlans: make(buildplan.BuildPlan),
+		TouchedLayers:        NewTouchedLayers(layers.Root, logger),
+		SourceDateEpoch:      sourceDateEpochFromEnv(),
+		buildpack:            buildpack,
+		buildpackCache:       buildpackCache,
+		logger:               logger,
+	}
+}
+
+// sourceDateEpochFromEnv returns the time represented by the SOURCE_DATE_EPOCH environment variable, or nil if it is
+// unset or malformed.  See https://reproducible-builds.org/specs/source-date-epoch/.
+func sourceDateEpochFromEnv() *time.Time {
+	v, ok := os.LookupEnv("SOURCE_DATE_EPOCH")
+	if !ok {
+		return nil
+	}
+
+	seconds, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	t := time.Unix(seconds, 0).UTC()
+	return &t
+}
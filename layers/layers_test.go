@@ -19,11 +19,15 @@ package layers_test
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
 	"path/filepath"
 	"testing"
+	"time"
 
 	layersBp "github.com/buildpack/libbuildpack/layers"
 	loggerBp "github.com/buildpack/libbuildpack/logger"
+	"github.com/cloudfoundry/libcfbuildpack/buildpack"
+	"github.com/cloudfoundry/libcfbuildpack/helper"
 	"github.com/cloudfoundry/libcfbuildpack/layers"
 	"github.com/cloudfoundry/libcfbuildpack/logger"
 	"github.com/cloudfoundry/libcfbuildpack/test"
@@ -47,7 +51,7 @@ func TestLayers(t *testing.T) {
 		it.Before(func() {
 			root = test.ScratchDir(t, "layers")
 			logger := logger.Logger{Logger: loggerBp.NewLogger(nil, &info)}
-			l = layers.NewLayers(layersBp.Layers{Root: root}, layersBp.Layers{}, logger)
+			l = layers.NewLayers(layersBp.Layers{Root: root}, layersBp.Layers{}, buildpack.Buildpack{}, logger)
 		})
 
 		it("logs process types", func() {
@@ -77,5 +81,57 @@ func TestLayers(t *testing.T) {
 			g.Expect(filepath.Join(l.Root, "test-layer-1.toml")).To(BeAnExistingFile())
 			g.Expect(filepath.Join(l.Root, "test-layer-2.toml")).NotTo(BeAnExistingFile())
 		})
+
+		it("writes a Syft JSON SBOM for a registered artifact", func() {
+			l.SBOMFormats = []layers.SBOMFormat{layers.SyftJSON}
+
+			layer := l.Layer("test-layer")
+			g.Expect(layer.Contribute(nil, func(layer layers.Layer) error {
+				layer.RegisterSBOMArtifact("library", "buildpack", layers.SBOMArtifact{Name: "test-artifact", Version: "1.0"})
+				return nil
+			})).To(Succeed())
+
+			g.Expect(layer).To(test.HaveSBOMArtifact(layers.SyftJSON, "test-artifact"))
+		})
+
+		it("writes a CycloneDX JSON SBOM for a registered artifact", func() {
+			l.SBOMFormats = []layers.SBOMFormat{layers.CycloneDXJSON}
+
+			layer := l.Layer("test-layer")
+			g.Expect(layer.Contribute(nil, func(layer layers.Layer) error {
+				layer.RegisterSBOMArtifact("library", "buildpack", layers.SBOMArtifact{Name: "test-artifact", Version: "1.0"})
+				return nil
+			})).To(Succeed())
+
+			g.Expect(layer).To(test.HaveSBOMArtifact(layers.CycloneDXJSON, "test-artifact"))
+		})
+
+		it("produces byte-identical layer trees across back-to-back reproducible contributions", func() {
+			epoch := time.Unix(0, 0).UTC()
+			l.SourceDateEpoch = &epoch
+
+			contribute := func() error {
+				return l.Layer("test-layer").Contribute(nil, func(layer layers.Layer) error {
+					return helper.WriteFile(filepath.Join(layer.Root, "file.txt"), 0644, "test-content")
+				})
+			}
+
+			g.Expect(contribute()).To(Succeed())
+			before, err := ioutil.ReadDir(l.Layer("test-layer").Root)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			time.Sleep(1100 * time.Millisecond)
+
+			g.Expect(contribute()).To(Succeed())
+			after, err := ioutil.ReadDir(l.Layer("test-layer").Root)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			g.Expect(after).To(HaveLen(len(before)))
+			for i := range before {
+				g.Expect(after[i].Name()).To(Equal(before[i].Name()))
+				g.Expect(after[i].ModTime()).To(Equal(before[i].ModTime()))
+				g.Expect(after[i].Mode()).To(Equal(before[i].Mode()))
+			}
+		})
 	}, spec.Report(report.Terminal{}))
 }
@@ -0,0 +1,181 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package layers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// SBOMFormat identifies a machine-readable software-bill-of-materials format that a layer's contributed artifact
+// can be described in.
+type SBOMFormat string
+
+const (
+	// SyftJSON is the Syft JSON SBOM format.
+	SyftJSON SBOMFormat = "syft"
+
+	// CycloneDXJSON is the CycloneDX JSON SBOM format.
+	CycloneDXJSON SBOMFormat = "cyclonedx"
+)
+
+// SBOMArtifact describes a single artifact contributed by a layer, in a form that can be rendered into any of the
+// formats in SBOMFormat.
+type SBOMArtifact struct {
+	// Name is the name of the artifact.
+	Name string
+
+	// Version is the version of the artifact.
+	Version string
+
+	// Licenses are the licenses the artifact is distributed under.
+	Licenses []string
+
+	// PURL is the package-url (purl-spec) identifier for the artifact.
+	PURL string
+
+	// CPEs are the CPE 2.3 identifiers for the artifact.
+	CPEs []string
+}
+
+// sbomRegistration pairs an SBOMArtifact with the additional context a LayerContributor has about how it was found.
+type sbomRegistration struct {
+	artifactType string
+	foundBy      string
+	artifact     SBOMArtifact
+}
+
+// syftArtifact is a single entry in a Syft JSON document.
+type syftArtifact struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Version   string   `json:"version"`
+	Type      string   `json:"type"`
+	FoundBy   string   `json:"foundBy"`
+	Locations []string `json:"locations"`
+	Licenses  []string `json:"licenses"`
+	Language  string   `json:"language"`
+	CPEs      []string `json:"cpes"`
+	PURL      string   `json:"purl"`
+}
+
+type syftDocument struct {
+	Artifacts []syftArtifact `json:"artifacts"`
+}
+
+// cycloneDXLicense is a single license entry in a CycloneDX component.
+type cycloneDXLicense struct {
+	License struct {
+		ID string `json:"id"`
+	} `json:"license"`
+}
+
+// cycloneDXComponent is a single component entry in a CycloneDX BOM.
+type cycloneDXComponent struct {
+	BOMRef   string             `json:"bom-ref"`
+	Type     string             `json:"type"`
+	Name     string             `json:"name"`
+	Version  string             `json:"version"`
+	Licenses []cycloneDXLicense `json:"licenses,omitempty"`
+	PURL     string             `json:"purl,omitempty"`
+	CPEs     []string           `json:"cpes,omitempty"`
+}
+
+type cycloneDXDocument struct {
+	BOMFormat   string               `json:"bomFormat"`
+	SpecVersion string               `json:"specVersion"`
+	Components  []cycloneDXComponent `json:"components"`
+}
+
+// sbomID computes a stable identifier for an artifact from its contents.
+func sbomID(artifact SBOMArtifact) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s|%s|%s|%s", artifact.Name, artifact.Version, artifact.PURL, strings.Join(artifact.CPEs, ","))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeSBOM writes a `<layer>.sbom.<format>.json` file next to metadataPath, a layer's TOML metadata file, for each
+// requested format, describing a single artifact found at locations.
+func writeSBOM(metadataPath string, locations []string, registration sbomRegistration, formats []SBOMFormat) error {
+	base := strings.TrimSuffix(metadataPath, ".toml")
+	id := sbomID(registration.artifact)
+
+	for _, format := range formats {
+		var (
+			contents interface{}
+			path     string
+		)
+
+		switch format {
+		case SyftJSON:
+			path = fmt.Sprintf("%s.sbom.syft.json", base)
+			contents = syftDocument{
+				Artifacts: []syftArtifact{{
+					ID:        id,
+					Name:      registration.artifact.Name,
+					Version:   registration.artifact.Version,
+					Type:      registration.artifactType,
+					FoundBy:   registration.foundBy,
+					Locations: locations,
+					Licenses:  registration.artifact.Licenses,
+					CPEs:      registration.artifact.CPEs,
+					PURL:      registration.artifact.PURL,
+				}},
+			}
+		case CycloneDXJSON:
+			path = fmt.Sprintf("%s.sbom.cyclonedx.json", base)
+
+			var licenses []cycloneDXLicense
+			for _, l := range registration.artifact.Licenses {
+				var license cycloneDXLicense
+				license.License.ID = l
+				licenses = append(licenses, license)
+			}
+
+			contents = cycloneDXDocument{
+				BOMFormat:   "CycloneDX",
+				SpecVersion: "1.2",
+				Components: []cycloneDXComponent{{
+					BOMRef:   id,
+					Type:     registration.artifactType,
+					Name:     registration.artifact.Name,
+					Version:  registration.artifact.Version,
+					Licenses: licenses,
+					PURL:     registration.artifact.PURL,
+					CPEs:     registration.artifact.CPEs,
+				}},
+			}
+		default:
+			return fmt.Errorf("unsupported SBOM format %s", format)
+		}
+
+		b, err := json.MarshalIndent(contents, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		if err := ioutil.WriteFile(path, b, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package layers
+
+import (
+	"github.com/buildpack/libbuildpack/layers"
+)
+
+const (
+	// Build indicates that a layer should be used for builds
+	Build = layers.Build
+
+	// Cache indicates that a layer should be cached
+	Cache = layers.Cache
+
+	// Launch indicates that a layer should be used for launch
+	Launch = layers.Launch
+)
+
+type Flag = layers.Flag
+
+type Metadata = layers.Metadata
+
+type Processes = layers.Processes
+
+type Process = layers.Process
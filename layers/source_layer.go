@@ -0,0 +1,91 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package layers
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/cloudfoundry/libcfbuildpack/buildpack"
+	"github.com/cloudfoundry/libcfbuildpack/logger"
+	"github.com/fatih/color"
+)
+
+// SourceLayer is an extension to Layer that is unique to a dependency's optional source artifact, cached in a layer
+// sibling to the dependency's binary DownloadLayer.
+type SourceLayer struct {
+	Layer
+
+	cacheLayer Layer
+	dependency buildpack.Dependency
+	logger     logger.Logger
+}
+
+// Artifact returns the path to the dependency's source artifact, downloading and verifying it against SourceSHA256
+// if it has not already been cached.  If the dependency does not declare a Source, it returns an empty string
+// without error.
+func (l SourceLayer) Artifact() (string, error) {
+	if l.dependency.Source == "" {
+		return "", nil
+	}
+
+	l.Touch()
+
+	matches, err := l.cacheLayer.MetadataMatches(l.dependency)
+	if err != nil {
+		return "", err
+	}
+
+	artifact := filepath.Join(l.cacheLayer.Root, filepath.Base(l.dependency.Source))
+	if matches {
+		l.logger.SubsequentLine("%s cached source download from buildpack", color.GreenString("Reusing"))
+		return artifact, nil
+	}
+
+	matches, err = l.MetadataMatches(l.dependency)
+	if err != nil {
+		return "", err
+	}
+
+	artifact = filepath.Join(l.Root, filepath.Base(l.dependency.Source))
+	if matches {
+		l.logger.SubsequentLine("%s cached source download from previous build", color.GreenString("Reusing"))
+		return artifact, nil
+	}
+
+	l.logger.SubsequentLine("%s source from %s", color.YellowString("Downloading"), l.dependency.Source)
+	if err := downloadArtifact(l.dependency.Source, artifact); err != nil {
+		return "", err
+	}
+
+	l.logger.SubsequentLine("Verifying source checksum")
+	if err := verifyArtifact(artifact, l.dependency.SourceSHA256); err != nil {
+		return "", err
+	}
+
+	if err := l.WriteMetadata(l.dependency, Cache); err != nil {
+		return "", err
+	}
+
+	return artifact, nil
+}
+
+// String makes SourceLayer satisfy the Stringer interface.
+func (l SourceLayer) String() string {
+	return fmt.Sprintf("SourceLayer{ Layer: %s, cacheLayer: %s, dependency: %s, logger: %s }",
+		l.Layer, l.cacheLayer, l.dependency, l.logger)
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package layers_test
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	layersBp "github.com/buildpack/libbuildpack/layers"
+	"github.com/cloudfoundry/libcfbuildpack/buildpack"
+	"github.com/cloudfoundry/libcfbuildpack/internal"
+	"github.com/cloudfoundry/libcfbuildpack/layers"
+	"github.com/cloudfoundry/libcfbuildpack/logger"
+	"github.com/cloudfoundry/libcfbuildpack/test"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+)
+
+func TestSourceLayer(t *testing.T) {
+	spec.Run(t, "SourceLayer", func(t *testing.T, _ spec.G, it spec.S) {
+
+		g := NewGomegaWithT(t)
+
+		var (
+			root       string
+			dependency buildpack.Dependency
+			layer      layers.SourceLayer
+			server     *ghttp.Server
+		)
+
+		it.Before(func() {
+			root = test.ScratchDir(t, "source-layer")
+
+			server = ghttp.NewServer()
+
+			dependency = buildpack.Dependency{
+				ID:           "test-id",
+				Version:      internal.NewTestVersion(t, "1.0"),
+				SHA256:       "test-sha256",
+				URI:          fmt.Sprintf("%s/test-path", server.URL()),
+				Source:       fmt.Sprintf("%s/test-source-path", server.URL()),
+				SourceSHA256: "6f06dd0e26608013eff30bb1e951cda7de3fdd9e78e907470e0dd5c0ed25e273",
+			}
+
+			ls := layers.NewLayers(layersBp.Layers{Root: root}, layersBp.Layers{Root: filepath.Join(root, "buildpack")}, buildpack.Buildpack{}, logger.Logger{})
+			layer = ls.SourceLayer(dependency)
+		})
+
+		it.After(func() {
+			server.Close()
+		})
+
+		it("creates a source layer sibling to the dependency's download layer", func() {
+			g.Expect(layer.Root).To(Equal(filepath.Join(root, fmt.Sprintf("%s-source", dependency.SHA256))))
+		})
+
+		it("returns an empty string without error when the dependency declares no source", func() {
+			dependency.Source = ""
+			layer = layers.NewLayers(layersBp.Layers{Root: root}, layersBp.Layers{}, buildpack.Buildpack{}, logger.Logger{}).SourceLayer(dependency)
+
+			artifact, err := layer.Artifact()
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(artifact).To(Equal(""))
+		})
+
+		it("downloads and verifies the source artifact", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusOK, "test-payload"))
+
+			g.Expect(layer.Artifact()).To(SatisfyAll(
+				Equal(filepath.Join(layer.Root, "test-source-path")),
+				test.HaveContent("test-payload")))
+		})
+
+		it("does not download a buildpack cached source artifact", func() {
+			test.WriteFile(t, filepath.Join(root, "buildpack", fmt.Sprintf("%s-source.toml", dependency.SHA256)), `[metadata]
+ID = "%s"
+Version = "%s"
+SHA256 = "%s"
+URI = "%s"
+Source = "%s"
+source_sha256 = "%s"`, dependency.ID, dependency.Version.Original(), dependency.SHA256, dependency.URI, dependency.Source, dependency.SourceSHA256)
+
+			g.Expect(layer.Artifact()).To(Equal(filepath.Join(root, "buildpack", fmt.Sprintf("%s-source", dependency.SHA256), "test-source-path")))
+		})
+
+		it("does not download a previously cached source artifact", func() {
+			test.WriteFile(t, layer.Metadata, `[metadata]
+ID = "%s"
+Version = "%s"
+SHA256 = "%s"
+URI = "%s"
+Source = "%s"
+source_sha256 = "%s"`, dependency.ID, dependency.Version.Original(), dependency.SHA256, dependency.URI, dependency.Source, dependency.SourceSHA256)
+
+			g.Expect(layer.Artifact()).To(Equal(filepath.Join(layer.Root, "test-source-path")))
+		})
+	}, spec.Report(report.Terminal{}))
+}
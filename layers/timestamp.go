@@ -0,0 +1,53 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package layers
+
+import "time"
+
+// Timestamp selects which modification time is applied to files written into a layer once reproducibility has been
+// enabled for it, i.e. once Layers.SourceDateEpoch has been configured, either explicitly or via the
+// SOURCE_DATE_EPOCH environment variable.
+type Timestamp int
+
+const (
+	// SourceTimestamp applies Layers.SourceDateEpoch to every file written into the layer.  It is the default.
+	SourceTimestamp Timestamp = iota
+
+	// Zero applies the UNIX epoch (1970-01-01T00:00:00Z) to every file written into the layer.
+	Zero
+
+	// BuildTimestamp applies the current time to every file written into the layer, opting the layer out of
+	// reproducible timestamps even when Layers.SourceDateEpoch is configured.
+	BuildTimestamp
+)
+
+// resolve returns the timestamp that t represents given sourceDateEpoch, and whether a layer configured with t
+// should have its contents normalized at all.
+func (t Timestamp) resolve(sourceDateEpoch *time.Time) (time.Time, bool) {
+	switch t {
+	case Zero:
+		return time.Unix(0, 0).UTC(), true
+	case BuildTimestamp:
+		return time.Now(), true
+	default:
+		if sourceDateEpoch == nil {
+			return time.Time{}, false
+		}
+
+		return *sourceDateEpoch, true
+	}
+}
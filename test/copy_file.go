@@ -0,0 +1,42 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"os"
+	"testing"
+)
+
+// CopyFile copies source to destination.  Before writing, it creates all required parent directories for the
+// destination.
+func CopyFile(t *testing.T, source string, destination string) {
+	t.Helper()
+
+	s, err := os.Open(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer s.Close()
+
+	i, err := s.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	WriteFileFromReader(t, destination, i.Mode(), s)
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"fmt"
+
+	"github.com/cloudfoundry/libcfbuildpack/buildpack"
+	"github.com/onsi/gomega/types"
+)
+
+// HaveDeprecationStatus tests that a Dependency has a specific DeprecationStatus.
+func HaveDeprecationStatus(status buildpack.DeprecationStatus) types.GomegaMatcher {
+	return &haveDeprecationStatusMatcher{status: status}
+}
+
+type haveDeprecationStatusMatcher struct {
+	status buildpack.DeprecationStatus
+}
+
+func (m *haveDeprecationStatusMatcher) Match(actual interface{}) (bool, error) {
+	dependency, ok := actual.(buildpack.Dependency)
+	if !ok {
+		return false, fmt.Errorf("HaveDeprecationStatus matcher expects a buildpack.Dependency")
+	}
+
+	return dependency.DeprecationStatus() == m.status, nil
+}
+
+func (m *haveDeprecationStatusMatcher) FailureMessage(actual interface{}) string {
+	return fmt.Sprintf("Expected\n\t%#v\nto have deprecation status %d", actual, m.status)
+}
+
+func (m *haveDeprecationStatusMatcher) NegatedFailureMessage(actual interface{}) string {
+	return fmt.Sprintf("Expected\n\t%#v\nnot to have deprecation status %d", actual, m.status)
+}
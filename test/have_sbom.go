@@ -0,0 +1,127 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+
+	"github.com/cloudfoundry/libcfbuildpack/layers"
+	"github.com/onsi/gomega/types"
+)
+
+// HaveSBOMArtifact tests that a layer has written a software-bill-of-materials entry for a named artifact in a given
+// format.
+func HaveSBOMArtifact(format layers.SBOMFormat, name string) types.GomegaMatcher {
+	return &haveSBOMArtifactMatcher{format: format, name: name}
+}
+
+type haveSBOMArtifactMatcher struct {
+	format layers.SBOMFormat
+	name   string
+}
+
+func (m *haveSBOMArtifactMatcher) Match(actual interface{}) (bool, error) {
+	path, err := m.path(actual)
+	if err != nil {
+		return false, err
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return false, nil
+	}
+
+	names, err := m.artifactNames(b)
+	if err != nil {
+		return false, err
+	}
+
+	for _, n := range names {
+		if n == m.name {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (m *haveSBOMArtifactMatcher) FailureMessage(actual interface{}) string {
+	return fmt.Sprintf("Expected\n\t%#v\nto have a %s SBOM artifact named %s", actual, m.format, m.name)
+}
+
+func (m *haveSBOMArtifactMatcher) NegatedFailureMessage(actual interface{}) string {
+	return fmt.Sprintf("Expected\n\t%#v\nnot to have a %s SBOM artifact named %s", actual, m.format, m.name)
+}
+
+func (m *haveSBOMArtifactMatcher) artifactNames(b []byte) ([]string, error) {
+	var names []string
+
+	switch m.format {
+	case layers.SyftJSON:
+		var doc struct {
+			Artifacts []struct {
+				Name string `json:"name"`
+			} `json:"artifacts"`
+		}
+		if err := json.Unmarshal(b, &doc); err != nil {
+			return nil, err
+		}
+		for _, a := range doc.Artifacts {
+			names = append(names, a.Name)
+		}
+
+	case layers.CycloneDXJSON:
+		var doc struct {
+			Components []struct {
+				Name string `json:"name"`
+			} `json:"components"`
+		}
+		if err := json.Unmarshal(b, &doc); err != nil {
+			return nil, err
+		}
+		for _, c := range doc.Components {
+			names = append(names, c.Name)
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported SBOM format %s", m.format)
+	}
+
+	return names, nil
+}
+
+func (m *haveSBOMArtifactMatcher) path(actual interface{}) (string, error) {
+	v := reflect.ValueOf(actual).FieldByName("Metadata")
+	if v == (reflect.Value{}) {
+		return "", fmt.Errorf("HaveSBOMArtifact matcher expects a layer")
+	}
+
+	base := strings.TrimSuffix(v.Interface().(string), ".toml")
+
+	switch m.format {
+	case layers.SyftJSON:
+		return fmt.Sprintf("%s.sbom.syft.json", base), nil
+	case layers.CycloneDXJSON:
+		return fmt.Sprintf("%s.sbom.cyclonedx.json", base), nil
+	default:
+		return "", fmt.Errorf("unsupported SBOM format %s", m.format)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cloudfoundry/libcfbuildpack/layers"
+	"github.com/onsi/gomega/types"
+)
+
+// HaveSource tests that a layers.DependencyLayer's source artifact was cached and that its contents hash to the
+// expected SHA256.
+func HaveSource(expectedSHA256 string) types.GomegaMatcher {
+	return &haveSourceMatcher{expectedSHA256: expectedSHA256}
+}
+
+type haveSourceMatcher struct {
+	expectedSHA256 string
+}
+
+func (m *haveSourceMatcher) Match(actual interface{}) (bool, error) {
+	layer, ok := actual.(layers.DependencyLayer)
+	if !ok {
+		return false, fmt.Errorf("HaveSource matcher expects a layers.DependencyLayer")
+	}
+
+	path, err := layer.Source()
+	if err != nil {
+		return false, err
+	}
+
+	if path == "" {
+		return false, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return false, nil
+	}
+	defer f.Close()
+
+	s := sha256.New()
+	if _, err := io.Copy(s, f); err != nil {
+		return false, err
+	}
+
+	return hex.EncodeToString(s.Sum(nil)) == m.expectedSHA256, nil
+}
+
+func (m *haveSourceMatcher) FailureMessage(actual interface{}) string {
+	return fmt.Sprintf("Expected\n\t%#v\nto have a cached source artifact with SHA256 %s", actual, m.expectedSHA256)
+}
+
+func (m *haveSourceMatcher) NegatedFailureMessage(actual interface{}) string {
+	return fmt.Sprintf("Expected\n\t%#v\nnot to have a cached source artifact with SHA256 %s", actual, m.expectedSHA256)
+}
@@ -20,8 +20,10 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"io/ioutil"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/buildpack/libbuildpack/buildplan"
 	bp "github.com/buildpack/libbuildpack/layers"
@@ -45,6 +47,11 @@ type BuildFactory struct {
 	// Output is the BuildPlan output at termination.
 	Output buildplan.BuildPlan
 
+	// Target is the OS/architecture/distribution to simulate as currently available to the application.  Set it
+	// before calling AddDependencyWithTargets or Build.Dependency to simulate resolving against a particular
+	// target, e.g. buildpack.Target{OS: "linux", Arch: "arm64", Distribution: buildpack.Distribution{Name: "ubuntu", Version: "22.04"}}.
+	Target buildpack.Target
+
 	t *testing.T
 }
 
@@ -76,6 +83,51 @@ func (f *BuildFactory) AddDependencyWithVersion(id string, version string, fixtu
 	f.addDependency(d)
 }
 
+// AddDependencyWithTargets adds a dependency with a version and explicit Targets to the buildpack metadata and
+// copies a fixture into a cached dependency layer.  Set f.Target before calling AddDependencyWithTargets to simulate
+// resolving against a particular OS/architecture/distribution, e.g. buildpack.Target{OS: "linux", Arch: "arm64",
+// Distribution: buildpack.Distribution{Name: "ubuntu", Version: "22.04"}}.
+func (f *BuildFactory) AddDependencyWithTargets(id string, version string, fixturePath string, targets ...buildpack.Target) {
+	f.t.Helper()
+
+	f.Build.Target = f.Target
+
+	d := f.newDependency(id, version, filepath.Base(fixturePath))
+	d.Targets = targets
+	f.cacheFixture(d, fixturePath)
+	f.addDependency(d)
+}
+
+// AddDependencyWithDeprecationDate adds a dependency with version 1.0 and a deprecation date to the buildpack
+// metadata and copies a fixture into a cached dependency layer.
+func (f *BuildFactory) AddDependencyWithDeprecationDate(id string, fixturePath string, deprecationDate time.Time) {
+	f.t.Helper()
+
+	d := f.newDependency(id, "1.0", filepath.Base(fixturePath))
+	d.DeprecationDate = &deprecationDate
+	f.cacheFixture(d, fixturePath)
+	f.addDependency(d)
+}
+
+// AddDependencyWithSource adds a dependency with a version to the buildpack metadata, copying binaryFixture into a
+// cached dependency layer and sourceFixture into a cached source layer alongside it.
+func (f *BuildFactory) AddDependencyWithSource(id string, version string, binaryFixture string, sourceFixture string) {
+	f.t.Helper()
+
+	d := f.newDependency(id, version, filepath.Base(binaryFixture))
+	d.Source = fmt.Sprintf("http://localhost/%s", filepath.Base(sourceFixture))
+
+	contents, err := ioutil.ReadFile(sourceFixture)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	d.SourceSHA256 = fmt.Sprintf("%x", sha256.Sum256(contents))
+
+	f.cacheFixture(d, binaryFixture)
+	f.cacheSourceFixture(d, sourceFixture)
+	f.addDependency(d)
+}
+
 func (f *BuildFactory) addDependency(dependency buildpack.Dependency) {
 	f.t.Helper()
 
@@ -95,25 +147,53 @@ func (f *BuildFactory) addDependency(dependency buildpack.Dependency) {
 		stacks = append(stacks, stack)
 	}
 
-	var licenses []map[string]interface{}
+	var licenses []interface{}
 	for _, license := range dependency.Licenses {
-		licenses = append(licenses, map[string]interface{}{
-			"type": license.Type,
-			"uri":  license.URI,
-		})
+		licenses = append(licenses, licenseMap(license))
 	}
 
 	metadata["dependencies"] = append(dependencies, map[string]interface{}{
-		"id":       dependency.ID,
-		"name":     dependency.Name,
-		"version":  dependency.Version.Version.Original(),
-		"uri":      dependency.URI,
-		"sha256":   dependency.SHA256,
-		"stacks":   stacks,
-		"licenses": licenses,
+		"id":               dependency.ID,
+		"name":             dependency.Name,
+		"version":          dependency.Version.Version.Original(),
+		"uri":              dependency.URI,
+		"sha256":           dependency.SHA256,
+		"stacks":           stacks,
+		"targets":          dependency.Targets,
+		"licenses":         licenses,
+		"purl":             dependency.PURL,
+		"cpes":             dependency.CPEs,
+		"source":           dependency.Source,
+		"source_sha256":    dependency.SourceSHA256,
+		"deprecation-date": dependency.DeprecationDate,
 	})
 }
 
+// licenseMap converts a license back into the shape it was most likely authored in: a bare SPDX identifier string
+// when only SPDXID is set, or a map of whichever fields are populated otherwise.
+func licenseMap(license buildpack.License) interface{} {
+	if license.SPDXID != "" && license.Type == "" && license.Name == "" && license.URI == "" {
+		return license.SPDXID
+	}
+
+	m := make(map[string]interface{})
+
+	if license.Type != "" {
+		m["type"] = license.Type
+	}
+	if license.SPDXID != "" {
+		m["spdx-id"] = license.SPDXID
+	}
+	if license.Name != "" {
+		m["name"] = license.Name
+	}
+	if license.URI != "" {
+		m["uri"] = license.URI
+	}
+
+	return m
+}
+
 func (f *BuildFactory) cacheFixture(dependency buildpack.Dependency, fixturePath string) {
 	f.t.Helper()
 
@@ -127,6 +207,21 @@ func (f *BuildFactory) cacheFixture(dependency buildpack.Dependency, fixturePath
 	}
 }
 
+// cacheSourceFixture seeds the cached source layer sibling to dependency's cached dependency layer, so that
+// DependencyLayer.Source can resolve it without downloading.
+func (f *BuildFactory) cacheSourceFixture(dependency buildpack.Dependency, fixturePath string) {
+	f.t.Helper()
+
+	l := f.Build.Layers.Layer(fmt.Sprintf("%s-source", dependency.SHA256))
+	if err := helper.CopyFile(fixturePath, filepath.Join(l.Root, filepath.Base(dependency.Source))); err != nil {
+		f.t.Fatal(err)
+	}
+
+	if err := internal.WriteTomlFile(l.Metadata, 0644, map[string]interface{}{"metadata": dependency}); err != nil {
+		f.t.Fatal(err)
+	}
+}
+
 func (f *BuildFactory) newDependency(id string, version string, name string) buildpack.Dependency {
 	f.t.Helper()
 
@@ -157,7 +252,14 @@ func NewBuildFactory(t *testing.T) *BuildFactory {
 	f.Build.Layers = layers.NewLayers(
 		bp.Layers{Root: filepath.Join(root, "layers")},
 		bp.Layers{Root: filepath.Join(root, "buildpack-cache")},
+		f.Build.Buildpack,
 		logger.Logger{})
+
+	// Default to the UNIX epoch so that contributions made during a test are reproducible, regardless of whether
+	// SOURCE_DATE_EPOCH is set in the test environment.
+	epoch := time.Unix(0, 0).UTC()
+	f.Build.Layers.SourceDateEpoch = &epoch
+
 	f.Build.Platform.Root = filepath.Join(root, "platform")
 	f.Build.Stack = stack.Stack("test-stack")
 
@@ -0,0 +1,135 @@
+/*
+ * Copyright 2018 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	bp "github.com/buildpack/libbuildpack/layers"
+	"github.com/buildpack/libbuildpack/platform"
+	"github.com/cloudfoundry/libcfbuildpack/buildpack"
+	"github.com/cloudfoundry/libcfbuildpack/extension"
+	"github.com/cloudfoundry/libcfbuildpack/helper"
+	"github.com/cloudfoundry/libcfbuildpack/internal"
+	"github.com/cloudfoundry/libcfbuildpack/layers"
+	"github.com/cloudfoundry/libcfbuildpack/logger"
+)
+
+// GenerateFactory is a factory for creating a test Generate.
+type GenerateFactory struct {
+	// Generate is the configured generate to use.
+	Generate extension.Generate
+
+	t *testing.T
+}
+
+// AddDependency adds a dependency with version 1.0 to the extension metadata and copies a fixture into a cached
+// dependency layer.
+func (f *GenerateFactory) AddDependency(id string, fixturePath string) {
+	f.t.Helper()
+
+	d := buildpack.Dependency{
+		ID:      id,
+		Name:    filepath.Base(fixturePath),
+		Version: internal.NewTestVersion(f.t, "1.0"),
+		SHA256:  hex.EncodeToString(sha256.New().Sum([]byte(id))),
+		URI:     fmt.Sprintf("http://localhost/%s", filepath.Base(fixturePath)),
+	}
+
+	l := f.Generate.Layers.Layer(d.SHA256)
+	if err := helper.CopyFile(fixturePath, filepath.Join(l.Root, d.Name)); err != nil {
+		f.t.Fatal(err)
+	}
+
+	if err := internal.WriteTomlFile(l.Metadata, 0644, map[string]interface{}{"metadata": d}); err != nil {
+		f.t.Fatal(err)
+	}
+
+	f.addDependency(d)
+}
+
+func (f *GenerateFactory) addDependency(dependency buildpack.Dependency) {
+	f.t.Helper()
+
+	if f.Generate.Extension.Metadata == nil {
+		f.Generate.Extension.Metadata = make(buildpack.Metadata)
+	}
+
+	if _, ok := f.Generate.Extension.Metadata["dependencies"]; !ok {
+		f.Generate.Extension.Metadata["dependencies"] = make([]map[string]interface{}, 0)
+	}
+
+	metadata := f.Generate.Extension.Metadata
+	dependencies := metadata["dependencies"].([]map[string]interface{})
+
+	metadata["dependencies"] = append(dependencies, map[string]interface{}{
+		"id":      dependency.ID,
+		"name":    dependency.Name,
+		"version": dependency.Version.Version.Original(),
+		"uri":     dependency.URI,
+		"sha256":  dependency.SHA256,
+	})
+}
+
+// BuildDockerfile returns the contents of the build-time Dockerfile fragment written to Generate.Output.
+func (f *GenerateFactory) BuildDockerfile() string {
+	f.t.Helper()
+	return f.dockerfile("Dockerfile")
+}
+
+// RunDockerfile returns the contents of the run-time Dockerfile fragment written to Generate.Output.
+func (f *GenerateFactory) RunDockerfile() string {
+	f.t.Helper()
+	return f.dockerfile("run.Dockerfile")
+}
+
+func (f *GenerateFactory) dockerfile(name string) string {
+	f.t.Helper()
+
+	b, err := ioutil.ReadFile(filepath.Join(f.Generate.Output, name))
+	if err != nil {
+		f.t.Fatal(err)
+	}
+
+	return string(b)
+}
+
+// NewGenerateFactory creates a new instance of GenerateFactory.
+func NewGenerateFactory(t *testing.T) *GenerateFactory {
+	t.Helper()
+
+	root := ScratchDir(t, "generate")
+
+	f := GenerateFactory{t: t}
+
+	f.Generate.Extension.Root = filepath.Join(root, "extension")
+	f.Generate.Extension.CacheRoot = filepath.Join(root, "extension-cache")
+	f.Generate.Output = filepath.Join(root, "generated")
+	f.Generate.Platform = platform.Platform{Root: filepath.Join(root, "platform")}
+	f.Generate.Layers = layers.NewLayers(
+		bp.Layers{Root: filepath.Join(root, "layers")},
+		bp.Layers{Root: filepath.Join(root, "extension-cache")},
+		buildpack.Buildpack{},
+		logger.Logger{})
+
+	return &f
+}